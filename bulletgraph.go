@@ -12,22 +12,38 @@
 //			canvas := svg.New(os.Stdout)
 //			canvas.Start(1024, 800)
 //			canvas.Rect(0, 0, 1024, 800, "fill:white")
-//			canvas.Gstyle("font-family:Calibri;font-size:18px")
 //			bg := bulletgraph.New(canvas)
 //			bg.Data = append(bg.Data, bulletgraph.Bdata{Title: "Test", Subtitle: "subtitle", Scale: "0,60,2", Qmeasure: "27,29", Cmeasure: 27.5, Measure: 28.5})
-//			bg.Drawbg(canvas)
-//			canvas.Gend()
+//			bg.Drawbg(bulletgraph.NewSVGRenderer(canvas, bg.Flags.Fontsize))
 //			canvas.End()
 //		}
 package bulletgraph
 
 import (
+	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"github.com/ajstarks/svgo"
+	"io"
+	"math"
+	"sort"
 	"strconv"
 	"strings"
 )
 
+// AxisType selects how a Bdata's scale is projected onto pixel space.
+type AxisType int
+
+const (
+	Linear AxisType = iota // even ticks across scalemin..scalemax
+	Log                    // decade (10^k) ticks, values mapped through log10
+	SymLog                 // linear within [-SymLogThresh, SymLogThresh], log10 beyond it
+)
+
+// SymLogThresh default: the half-width of the linear region a SymLog axis uses
+// around zero when Bdata.SymLogThresh is left at zero.
+const SymLogThresh = 1.0
+
 // Top: The top of the first bullet graph.
 // Left: The leftmost pixel to draw the bullet graph.
 // Right: The rightmost pixel to draw the bullet graph.
@@ -36,13 +52,14 @@ import (
 // Note: An array of notes to display at the bottom of the SVG.
 // Flag: Options for generating bullet graphs.
 type Bulletgraph struct {
-	Top   int
-	Left  int
-	Right int
-	Title string
-	Data  []Bdata
-	Note  []Note
-	Flags Options
+	XMLName xml.Name `xml:"bulletgraph" json:"-"`
+	Top     int      `xml:"top,attr,omitempty" json:"top,omitempty"`
+	Left    int      `xml:"left,attr,omitempty" json:"left,omitempty"`
+	Right   int      `xml:"right,attr,omitempty" json:"right,omitempty"`
+	Title   string   `xml:"title,attr,omitempty" json:"title,omitempty"`
+	Data    []Bdata  `xml:"data" json:"data,omitempty"`
+	Note    []Note   `xml:"note" json:"note,omitempty"`
+	Flags   Options  `xml:"options" json:"options,omitempty"`
 }
 
 // Title: Title of the bulletgraph. Displayed on the left of the bullet graph by default.
@@ -50,19 +67,49 @@ type Bulletgraph struct {
 // Scale: A delimited string indicating the start,end,increment values for the bullet graph e.g. "0,60,2".
 // Qmeasure: A delimited string indicating the comparison "zones" in the bullet graph e.g. "27,29".
 // Cmeasure: The comparison indicator in the bullet graph.
-// Measure: The measure for the bar to display. 
+// Measure: The measure for the bar to display.
+// Barcolor: Per-graph override for Options.Barcolor, used when non-empty.
+// Datacolor: Per-graph override for Options.Datacolor, used when non-empty.
+// Compcolor: Per-graph override for Options.Compcolor, used when non-empty.
+// Circleradius: Per-graph override for the circle mark radius, used when positive.
+// AxisType: Linear (default), Log, or SymLog scale projection.
+// SymLogThresh: Half-width of the SymLog axis's linear region; defaults to SymLogThresh when zero.
+// Pmeasure: Projected/forecast measure, drawn as a lighter overlay bar behind Measure;
+//           nil means "no projection", so an explicit 0 still draws on a reversed or
+//           negative scale instead of being mistaken for unset.
+// QmeasureColors: Explicit per-band colors for the qualitative bands, darkest band first;
+//                 when shorter than the number of bands, the remaining bands fall back to
+//                 Barcolor shaded from darker to lighter.
+// Tooltip: SVG tooltip shown over the measure bar when Options.Interactive is set; defaults
+//          to the measure formatted with the same scfmt tick labels use. The comparative
+//          mark's tooltip is always Cmeasure formatted that way; Tooltip does not apply to it.
+// Href: Optional hyperlink wrapped around the measure bar, comparative mark, and qualitative
+//       bands when Options.Interactive is set.
+// QmeasureTooltips: Per-band tooltips paired with the qualitative bands qualitativeBounds
+//                    produces; a band beyond the end of this slice gets no tooltip.
 type Bdata struct {
-	Title    string
-	Subtitle string
-	Scale    string
-	Qmeasure string
-	Cmeasure float64
-	Measure  float64
+	Title            string   `xml:"title,attr,omitempty" json:"title,omitempty"`
+	Subtitle         string   `xml:"subtitle,attr,omitempty" json:"subtitle,omitempty"`
+	Scale            string   `xml:"scale,attr,omitempty" json:"scale,omitempty"`
+	Qmeasure         string   `xml:"qmeasure,attr,omitempty" json:"qmeasure,omitempty"`
+	Cmeasure         float64  `xml:"cmeasure,attr,omitempty" json:"cmeasure,omitempty"`
+	Measure          float64  `xml:"measure,attr,omitempty" json:"measure,omitempty"`
+	Pmeasure         *float64 `xml:"pmeasure,attr,omitempty" json:"pmeasure,omitempty"`
+	Barcolor         string   `xml:"barcolor,attr,omitempty" json:"barcolor,omitempty"`
+	Datacolor        string   `xml:"datacolor,attr,omitempty" json:"datacolor,omitempty"`
+	Compcolor        string   `xml:"compcolor,attr,omitempty" json:"compcolor,omitempty"`
+	Circleradius     int      `xml:"circleradius,attr,omitempty" json:"circleradius,omitempty"`
+	AxisType         AxisType `xml:"axistype,attr,omitempty" json:"axistype,omitempty"`
+	SymLogThresh     float64  `xml:"symlogthresh,attr,omitempty" json:"symlogthresh,omitempty"`
+	QmeasureColors   []string `xml:"qmeasurecolor" json:"qmeasurecolors,omitempty"`
+	Tooltip          string   `xml:"tooltip,attr,omitempty" json:"tooltip,omitempty"`
+	Href             string   `xml:"href,attr,omitempty" json:"href,omitempty"`
+	QmeasureTooltips []string `xml:"qmeasuretooltip" json:"qmeasuretooltips,omitempty"`
 }
 
 // Text: Note to be displayed under the bullet graph.
 type Note struct {
-	Text string `xml:",chardata"`
+	Text string `xml:",chardata" json:"text,omitempty"`
 }
 
 // These options have default values some of which are derived from the canvas.
@@ -78,10 +125,30 @@ type Note struct {
 // Title: Title
 // Showtitle: Show title
 // Circlemark: Circle mark
+// Font: Font family used for the top-level group Drawbg opens
+// Gstyle: Style template applied to the top-level group, formatted with Font and Fontsize
+// Interactive: When set, Drawbg wraps the measure bar, comparative mark, and qualitative
+//              bands in SVG <title> tooltips and, where Bdata.Href is set, <a> hyperlinks.
+//              Renderers other than SVGRenderer ignore it.
+// Script: When Interactive is also set, additionally emit a small inline <script> that
+//         highlights the hovered bar and floats its tooltip text next to the pointer.
 type Options struct {
-	Width, Height, Fontsize, Barheight, Gutter     int
-	Bgcolor, Barcolor, Datacolor, Compcolor, Title string
-	Showtitle, Circlemark                          bool
+	Width        int    `xml:"width,attr,omitempty" json:"width,omitempty"`
+	Height       int    `xml:"height,attr,omitempty" json:"height,omitempty"`
+	Fontsize     int    `xml:"fontsize,attr,omitempty" json:"fontsize,omitempty"`
+	Barheight    int    `xml:"barheight,attr,omitempty" json:"barheight,omitempty"`
+	Gutter       int    `xml:"gutter,attr,omitempty" json:"gutter,omitempty"`
+	Bgcolor      string `xml:"bgcolor,attr,omitempty" json:"bgcolor,omitempty"`
+	Barcolor     string `xml:"barcolor,attr,omitempty" json:"barcolor,omitempty"`
+	Datacolor    string `xml:"datacolor,attr,omitempty" json:"datacolor,omitempty"`
+	Compcolor    string `xml:"compcolor,attr,omitempty" json:"compcolor,omitempty"`
+	Title        string `xml:"title,attr,omitempty" json:"title,omitempty"`
+	Showtitle    bool   `xml:"showtitle,attr,omitempty" json:"showtitle,omitempty"`
+	Circlemark   bool   `xml:"circlemark,attr,omitempty" json:"circlemark,omitempty"`
+	Font         string `xml:"font,attr,omitempty" json:"font,omitempty"`
+	Gstyle       string `xml:"gstyle,attr,omitempty" json:"gstyle,omitempty"`
+	Interactive  bool   `xml:"interactive,attr,omitempty" json:"interactive,omitempty"`
+	Script       bool   `xml:"script,attr,omitempty" json:"script,omitempty"`
 }
 
 // Creates a new Bulletgraph with default options.
@@ -96,16 +163,41 @@ func New(canvas *svg.SVG) *Bulletgraph {
 	bg.Flags.Barheight = 48                //bar height
 	bg.Flags.Gutter = 30                   //gutter
 	bg.Flags.Fontsize = 18                 //fontsize (px)
+	bg.Flags.Font = "Calibri"              //font family
+	bg.Flags.Gstyle = "font-family:'%s',sans-serif;font-size:%dpx" //top-level group style template
 	//bg.Flags.Circlemark 				   //circle mark
 	//bg.Flags.Showtitle 				   //show title
 	//bg.Flags.Title 					   //title
 	return &bg
 }
 
-// Drawbg draws the bullet graph onto the canvas
-func (bg *Bulletgraph) Drawbg(canvas *svg.SVG) {
+// LoadXML reads a bullet graph definition (title, flags, notes, and bar data) from
+// XML and returns a Bulletgraph ready to Drawbg. Fields omitted from the input keep
+// the defaults set by New.
+func LoadXML(r io.Reader) (*Bulletgraph, error) {
+	bg := New(nil)
+	if err := xml.NewDecoder(r).Decode(bg); err != nil {
+		return nil, err
+	}
+	return bg, nil
+}
+
+// LoadJSON reads a bullet graph definition (title, flags, notes, and bar data) from
+// JSON and returns a Bulletgraph ready to Drawbg. Fields omitted from the input keep
+// the defaults set by New.
+func LoadJSON(r io.Reader) (*Bulletgraph, error) {
+	bg := New(nil)
+	if err := json.NewDecoder(r).Decode(bg); err != nil {
+		return nil, err
+	}
+	return bg, nil
+}
+
+// Drawbg draws the bullet graph onto r
+func (bg *Bulletgraph) Drawbg(r Renderer) {
 	qmheight := bg.Flags.Barheight / 3
 
+	explicitLeft := bg.Left != 0
 	if bg.Left == 0 {
 		bg.Left = 250
 	}
@@ -119,13 +211,36 @@ func (bg *Bulletgraph) Drawbg(canvas *svg.SVG) {
 		bg.Title = bg.Flags.Title
 	}
 
+	r.Group(fmt.Sprintf(bg.Flags.Gstyle, bg.Flags.Font, bg.Flags.Fontsize))
+	r.Title(bg.Title)
+	if bg.Flags.Interactive && bg.Flags.Script {
+		r.Script(interactiveScript)
+	}
+
+	// auto-size the left margin from the longest rendered label so labels don't
+	// overflow it on fonts whose glyph metrics differ from Calibri's
+	gutter := bg.Flags.Fontsize / 3
+	if gutter < 4 {
+		gutter = 4
+	}
+	if !explicitLeft {
+		for _, v := range bg.Data {
+			label := fmt.Sprintf("%s (%g)", v.Title, v.Measure)
+			if w := r.MeasureText(label) + gutter; w > bg.Left {
+				bg.Left = w
+			}
+			if w := r.MeasureText(v.Subtitle) + gutter; w > bg.Left {
+				bg.Left = w
+			}
+		}
+	}
+
 	maxwidth := bg.Flags.Width - (bg.Left + bg.Right)
 	x := bg.Left
 	y := bg.Top
 	scalesep := 4
-	tx := x - bg.Flags.Fontsize
+	tx := x - gutter
 
-	canvas.Title(bg.Title)
 	// for each data element...
 	for _, v := range bg.Data {
 
@@ -137,6 +252,24 @@ func (bg *Bulletgraph) Drawbg(canvas *svg.SVG) {
 		if len(sc) != 3 || len(qm) < 1 {
 			continue
 		}
+
+		// per-graph overrides fall back to the shared options when unset
+		barcolor := bg.Flags.Barcolor
+		if len(v.Barcolor) > 0 {
+			barcolor = v.Barcolor
+		}
+		datacolor := bg.Flags.Datacolor
+		if len(v.Datacolor) > 0 {
+			datacolor = v.Datacolor
+		}
+		compcolor := bg.Flags.Compcolor
+		if len(v.Compcolor) > 0 {
+			compcolor = v.Compcolor
+		}
+		circleradius := bg.Flags.Barheight / 6
+		if v.Circleradius > 0 {
+			circleradius = v.Circleradius
+		}
 		// get the qualitative measures
 		qmeasures := make([]float64, len(qm))
 		for i, q := range qm {
@@ -145,41 +278,85 @@ func (bg *Bulletgraph) Drawbg(canvas *svg.SVG) {
 		scalemin, _ := strconv.ParseFloat(sc[0], 64)
 		scalemax, _ := strconv.ParseFloat(sc[1], 64)
 		scaleincr, _ := strconv.ParseFloat(sc[2], 64)
+		symlogt := v.SymLogThresh
+		if symlogt <= 0 {
+			symlogt = SymLogThresh
+		}
 
 		// label the graph
-		canvas.Text(tx, y+bg.Flags.Barheight/3, fmt.Sprintf("%s (%g)", v.Title, v.Measure), "text-anchor:end;font-weight:bold")
-		canvas.Text(tx, y+(bg.Flags.Barheight/3)+bg.Flags.Fontsize, v.Subtitle, "text-anchor:end;font-size:75%")
+		r.Text(tx, y+bg.Flags.Barheight/3, fmt.Sprintf("%s (%g)", v.Title, v.Measure), "text-anchor:end;font-weight:bold")
+		r.Text(tx, y+(bg.Flags.Barheight/3)+bg.Flags.Fontsize, v.Subtitle, "text-anchor:end;font-size:75%")
 
 		// draw the scale
 		scfmt := "%g"
-		if fraction(scaleincr) > 0 {
+		if v.AxisType == Linear && fraction(scaleincr) > 0 {
 			scfmt = "%.1f"
 		}
-		canvas.Gstyle("text-anchor:middle;font-size:75%")
-		for sc := scalemin; sc <= scalemax; sc += scaleincr {
-			scx := vmap(sc, scalemin, scalemax, 0, float64(maxwidth))
-			canvas.Text(x+int(scx), y+scalesep+bg.Flags.Barheight+bg.Flags.Fontsize/2, fmt.Sprintf(scfmt, sc))
+		r.Group("text-anchor:middle;font-size:75%")
+		for _, sc := range axisticks(v.AxisType, scalemin, scalemax, scaleincr) {
+			scx := axisvmap(v.AxisType, sc, scalemin, scalemax, maxwidth, symlogt)
+			r.Text(x+int(scx), y+scalesep+bg.Flags.Barheight+bg.Flags.Fontsize/2, fmt.Sprintf(scfmt, sc), "")
+		}
+		r.EndGroup()
+
+		// draw the qualitative measures as stacked, contiguous bands shading from
+		// darker (near scalemin) to lighter (near scalemax)
+		bounds := qualitativeBounds(qmeasures, scalemin, scalemax)
+		bands := len(bounds) - 1
+		for i := 0; i < bands; i++ {
+			bandstart := axisvmap(v.AxisType, bounds[i], scalemin, scalemax, maxwidth, symlogt)
+			bandend := axisvmap(v.AxisType, bounds[i+1], scalemin, scalemax, maxwidth, symlogt)
+			style := fmt.Sprintf("fill-opacity:%.2f;fill:%s", bandOpacity(i, bands), barcolor)
+			if i < len(v.QmeasureColors) {
+				style = "fill:" + v.QmeasureColors[i]
+			}
+			var tooltip string
+			if i < len(v.QmeasureTooltips) {
+				tooltip = v.QmeasureTooltips[i]
+			}
+			if bg.Flags.Interactive {
+				r.StartInteractive(tooltip, v.Href)
+			}
+			r.Rect(x+int(bandstart), y, int(bandend-bandstart), bg.Flags.Barheight, style)
+			if bg.Flags.Interactive {
+				r.EndInteractive()
+			}
 		}
-		canvas.Gend()
 
-		// draw the qualitative measures
-		canvas.Gstyle("fill-opacity:0.5;fill:" + bg.Flags.Barcolor)
-		canvas.Rect(x, y, maxwidth, bg.Flags.Barheight)
-		for _, q := range qmeasures {
-			qbarlength := vmap(q, scalemin, scalemax, 0, float64(maxwidth))
-			canvas.Rect(x, y, int(qbarlength), bg.Flags.Barheight)
+		// draw the projected measure as a lighter overlay bar behind the actual measure
+		if v.Pmeasure != nil {
+			plength := int(axisvmap(v.AxisType, *v.Pmeasure, scalemin, scalemax, maxwidth, symlogt))
+			r.Rect(x, y+qmheight, plength, qmheight, "fill-opacity:0.4;fill:"+datacolor)
 		}
-		canvas.Gend()
 
 		// draw the measure and the comparative measure
-		barlength := int(vmap(v.Measure, scalemin, scalemax, 0, float64(maxwidth)))
-		canvas.Rect(x, y+qmheight, barlength, qmheight, "fill:"+bg.Flags.Datacolor)
-		cmx := int(vmap(v.Cmeasure, scalemin, scalemax, 0, float64(maxwidth)))
+		measureTooltip := v.Tooltip
+		if bg.Flags.Interactive && measureTooltip == "" {
+			measureTooltip = fmt.Sprintf(scfmt, v.Measure)
+		}
+		barlength := int(axisvmap(v.AxisType, v.Measure, scalemin, scalemax, maxwidth, symlogt))
+		if bg.Flags.Interactive {
+			r.StartInteractive(measureTooltip, v.Href)
+		}
+		r.Rect(x, y+qmheight, barlength, qmheight, "fill:"+datacolor)
+		if bg.Flags.Interactive {
+			r.EndInteractive()
+		}
+
+		cmx := int(axisvmap(v.AxisType, v.Cmeasure, scalemin, scalemax, maxwidth, symlogt))
+		compTooltip := ""
+		if bg.Flags.Interactive {
+			compTooltip = fmt.Sprintf(scfmt, v.Cmeasure)
+			r.StartInteractive(compTooltip, v.Href)
+		}
 		if bg.Flags.Circlemark {
-			canvas.Circle(x+cmx, y+bg.Flags.Barheight/2, bg.Flags.Barheight/6, "fill-opacity:0.3;fill:"+bg.Flags.Compcolor)
+			r.Circle(x+cmx, y+bg.Flags.Barheight/2, circleradius, "fill-opacity:0.3;fill:"+compcolor)
 		} else {
 			cbh := bg.Flags.Barheight / 4
-			canvas.Line(x+cmx, y+cbh, x+cmx, y+bg.Flags.Barheight-cbh, "stroke-width:3;stroke:"+bg.Flags.Compcolor)
+			r.Line(x+cmx, y+cbh, x+cmx, y+bg.Flags.Barheight-cbh, "stroke-width:3;stroke:"+compcolor)
+		}
+		if bg.Flags.Interactive {
+			r.EndInteractive()
 		}
 
 		y += bg.Flags.Barheight + bg.Flags.Gutter // adjust vertical position for the next iteration
@@ -187,19 +364,20 @@ func (bg *Bulletgraph) Drawbg(canvas *svg.SVG) {
 	// if requested, place the title below the last bar
 	if bg.Flags.Showtitle && len(bg.Title) > 0 {
 		y += bg.Flags.Fontsize * 2
-		canvas.Text(bg.Left, y, bg.Title, "text-anchor:start;font-size:200%")
+		r.Text(bg.Left, y, bg.Title, "text-anchor:start;font-size:200%")
 	}
 
 	if len(bg.Note) > 0 {
-		canvas.Gstyle("font-size:100%;text-anchor:start")
+		r.Group("font-size:100%;text-anchor:start")
 		y += bg.Flags.Fontsize * 2
 		leading := 3
 		for _, note := range bg.Note {
-			canvas.Text(bg.Left, y, note.Text)
+			r.Text(bg.Left, y, note.Text, "")
 			y += bg.Flags.Fontsize + leading
 		}
-		canvas.Gend()
+		r.EndGroup()
 	}
+	r.EndGroup()
 }
 
 //vmap maps one interval to another
@@ -207,8 +385,180 @@ func vmap(value float64, low1 float64, high1 float64, low2 float64, high2 float6
 	return low2 + (high2-low2)*(value-low1)/(high1-low1)
 }
 
+// clamp constrains value to the domain bounded by a and b, which may be given in
+// either order (a reversed scale has scalemin > scalemax).
+func clamp(value, a, b float64) float64 {
+	lo, hi := a, b
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	if value < lo {
+		return lo
+	}
+	if value > hi {
+		return hi
+	}
+	return value
+}
+
+// logfloor keeps a value strictly positive so it can be passed to log10; a
+// scalemin of zero or less on a Log/SymLog axis is floored to this epsilon.
+func logfloor(value float64) float64 {
+	const epsilon = 1e-9
+	if value <= 0 {
+		return epsilon
+	}
+	return value
+}
+
+// symlog maps value into symlog space: linear within [-t, t], and stitched to a
+// log10 tail beyond it so zero-crossings still render on a SymLog axis.
+func symlog(value, t float64) float64 {
+	switch {
+	case value > t:
+		return t + math.Log10(value/t)
+	case value < -t:
+		return -t - math.Log10(-value/t)
+	default:
+		return value
+	}
+}
+
+// axisvmap clamps value to [scalemin, scalemax] and maps it onto [0, maxwidth]
+// through the projection implied by axis.
+func axisvmap(axis AxisType, value, scalemin, scalemax float64, maxwidth int, symlogt float64) float64 {
+	value = clamp(value, scalemin, scalemax)
+	switch axis {
+	case Log:
+		lo, hi := logfloor(scalemin), logfloor(scalemax)
+		return vmap(math.Log10(logfloor(value)), math.Log10(lo), math.Log10(hi), 0, float64(maxwidth))
+	case SymLog:
+		return vmap(symlog(value, symlogt), symlog(scalemin, symlogt), symlog(scalemax, symlogt), 0, float64(maxwidth))
+	default:
+		return vmap(value, scalemin, scalemax, 0, float64(maxwidth))
+	}
+}
+
+// axisticks returns the major tick values for an axis. Linear and SymLog axes
+// step by scaleincr (honoring a reversed scalemin > scalemax); Log axes ignore
+// scaleincr and generate decade (10^k) ticks instead.
+func axisticks(axis AxisType, scalemin, scalemax, scaleincr float64) []float64 {
+	if axis == Log {
+		return decadeticks(logfloor(scalemin), logfloor(scalemax))
+	}
+	step := scaleincr
+	if step == 0 {
+		step = 1
+	}
+	reversed := scalemin > scalemax
+	if reversed {
+		step = -step
+	}
+	var ticks []float64
+	for sc := scalemin; (reversed && sc >= scalemax) || (!reversed && sc <= scalemax); sc += step {
+		ticks = append(ticks, sc)
+	}
+	return ticks
+}
+
+// decadeticks returns the 10^k major ticks spanning [lo, hi], which may be given
+// in reversed order for a reversed log axis.
+func decadeticks(lo, hi float64) []float64 {
+	min, max := lo, hi
+	reversed := lo > hi
+	if reversed {
+		min, max = hi, lo
+	}
+	start := math.Floor(math.Log10(min))
+	end := math.Ceil(math.Log10(max))
+	var ticks []float64
+	for k := start; k <= end; k++ {
+		t := math.Pow(10, k)
+		if t >= min && t <= max {
+			ticks = append(ticks, t)
+		}
+	}
+	if reversed {
+		for i, j := 0, len(ticks)-1; i < j; i, j = i+1, j-1 {
+			ticks[i], ticks[j] = ticks[j], ticks[i]
+		}
+	}
+	return ticks
+}
+
+// qualitativeBounds sorts and clamps qmeasures into the qualitative zone boundaries
+// for [scalemin, scalemax], returning scalemin and scalemax as the outer bounds so
+// callers get len(qmeasures)+1 contiguous bands. Boundaries run in the same
+// direction as the domain, so a reversed scale (scalemin > scalemax) still yields
+// bands running left to right on the rendered axis.
+func qualitativeBounds(qmeasures []float64, scalemin, scalemax float64) []float64 {
+	bounds := make([]float64, len(qmeasures))
+	for i, q := range qmeasures {
+		bounds[i] = clamp(q, scalemin, scalemax)
+	}
+	sort.Float64s(bounds)
+	if scalemin > scalemax {
+		for i, j := 0, len(bounds)-1; i < j; i, j = i+1, j-1 {
+			bounds[i], bounds[j] = bounds[j], bounds[i]
+		}
+	}
+	full := make([]float64, 0, len(bounds)+2)
+	full = append(full, scalemin)
+	full = append(full, bounds...)
+	full = append(full, scalemax)
+	return full
+}
+
+// bandOpacity returns the fill-opacity for qualitative band i of bands total,
+// shading from darker (i == 0) to lighter (i == bands-1).
+func bandOpacity(i, bands int) float64 {
+	const maxOpacity, minOpacity = 0.6, 0.2
+	if bands <= 1 {
+		return maxOpacity
+	}
+	return maxOpacity - (maxOpacity-minOpacity)*float64(i)/float64(bands-1)
+}
+
 // fraction returns the fractions portion of a floating point number
 func fraction(n float64) float64 {
 	i := int(n)
 	return n - float64(i)
 }
+
+// interactiveScript is the optional hover behavior Drawbg embeds when both
+// Options.Interactive and Options.Script are set: a CSS rule highlights the
+// hovered bar, band, or comparative mark, and a small vanilla-JS snippet
+// floats its <title> tooltip text next to the pointer. It reads the tooltip
+// straight out of the <title> child SVGRenderer.StartInteractive already
+// wrote (and svgo already XML-escaped), rather than duplicating that text
+// into a custom attribute, so it has no dependency on anything outside the
+// SVG document itself.
+const interactiveScript = `<style>.bg-interactive:hover{opacity:0.8}</style>
+<script><![CDATA[
+(function(){
+	var svg = document.currentScript.closest("svg");
+	if (!svg) return;
+	var readout = document.createElementNS("http://www.w3.org/2000/svg", "text");
+	readout.setAttribute("font-size", "12");
+	readout.style.display = "none";
+	svg.appendChild(readout);
+	Array.prototype.forEach.call(svg.querySelectorAll(".bg-interactive"), function(g) {
+		var title = g.querySelector("title");
+		if (!title) return;
+		g.addEventListener("mousemove", function(evt) {
+			var pt = svg.createSVGPoint();
+			pt.x = evt.clientX;
+			pt.y = evt.clientY;
+			var loc = pt.matrixTransform(svg.getScreenCTM().inverse());
+			readout.setAttribute("x", loc.x + 8);
+			readout.setAttribute("y", loc.y - 8);
+			readout.textContent = title.textContent;
+			readout.style.display = "block";
+		});
+		g.addEventListener("mouseout", function() {
+			readout.style.display = "none";
+		});
+	});
+})();
+]]></script>
+`
@@ -0,0 +1,179 @@
+package bulletgraph
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAxisvmapLinearReversed(t *testing.T) {
+	// a reversed domain (scalemin > scalemax) should still map scalemin to 0
+	// and scalemax to maxwidth, i.e. run left to right on the rendered axis.
+	cases := []struct {
+		value float64
+		want  float64
+	}{
+		{60, 0},
+		{0, 100},
+		{30, 50},
+	}
+	for _, c := range cases {
+		got := axisvmap(Linear, c.value, 60, 0, 100, SymLogThresh)
+		if math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("axisvmap(Linear, %g, 60, 0, 100) = %g, want %g", c.value, got, c.want)
+		}
+	}
+}
+
+func TestAxisvmapLinearNegative(t *testing.T) {
+	// a domain straddling zero should map its midpoint to the middle of maxwidth.
+	cases := []struct {
+		value float64
+		want  float64
+	}{
+		{-50, 0},
+		{0, 50},
+		{50, 100},
+	}
+	for _, c := range cases {
+		got := axisvmap(Linear, c.value, -50, 50, 100, SymLogThresh)
+		if math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("axisvmap(Linear, %g, -50, 50, 100) = %g, want %g", c.value, got, c.want)
+		}
+	}
+}
+
+func TestAxisvmapLinearClamps(t *testing.T) {
+	// values outside [scalemin, scalemax] clamp to the nearest edge rather than
+	// extrapolating off the rendered axis.
+	if got := axisvmap(Linear, 200, 0, 100, 100, SymLogThresh); got != 100 {
+		t.Errorf("axisvmap clamp above max = %g, want 100", got)
+	}
+	if got := axisvmap(Linear, -200, 0, 100, 100, SymLogThresh); got != 0 {
+		t.Errorf("axisvmap clamp below min = %g, want 0", got)
+	}
+}
+
+func TestAxisticksLinearReversed(t *testing.T) {
+	got := axisticks(Linear, 60, 0, 20)
+	want := []float64{60, 40, 20, 0}
+	if len(got) != len(want) {
+		t.Fatalf("axisticks(Linear, 60, 0, 20) = %v, want %v", got, want)
+	}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-9 {
+			t.Errorf("axisticks(Linear, 60, 0, 20)[%d] = %g, want %g", i, got[i], want[i])
+		}
+	}
+}
+
+func TestDecadeticks(t *testing.T) {
+	got := decadeticks(1, 1000)
+	want := []float64{1, 10, 100, 1000}
+	if len(got) != len(want) {
+		t.Fatalf("decadeticks(1, 1000) = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("decadeticks(1, 1000)[%d] = %g, want %g", i, got[i], want[i])
+		}
+	}
+}
+
+func TestDecadeticksReversed(t *testing.T) {
+	// a reversed log domain should return the same decades, outermost first.
+	got := decadeticks(1000, 1)
+	want := []float64{1000, 100, 10, 1}
+	if len(got) != len(want) {
+		t.Fatalf("decadeticks(1000, 1) = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("decadeticks(1000, 1)[%d] = %g, want %g", i, got[i], want[i])
+		}
+	}
+}
+
+func TestAxisvmapLog(t *testing.T) {
+	// log10(1)=0, log10(10)=1, log10(100)=2 evenly spaced across [0, maxwidth].
+	cases := []struct {
+		value float64
+		want  float64
+	}{
+		{1, 0},
+		{10, 50},
+		{100, 100},
+	}
+	for _, c := range cases {
+		got := axisvmap(Log, c.value, 1, 100, 100, SymLogThresh)
+		if math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("axisvmap(Log, %g, 1, 100, 100) = %g, want %g", c.value, got, c.want)
+		}
+	}
+}
+
+func TestSymlogZeroCrossing(t *testing.T) {
+	// within [-t, t] symlog is linear, so symlog(0, t) must be exactly 0 and the
+	// mapped value must sit at the midpoint of a symmetric domain.
+	if got := symlog(0, SymLogThresh); got != 0 {
+		t.Errorf("symlog(0, %g) = %g, want 0", SymLogThresh, got)
+	}
+	got := axisvmap(SymLog, 0, -100, 100, 100, SymLogThresh)
+	if math.Abs(got-50) > 1e-9 {
+		t.Errorf("axisvmap(SymLog, 0, -100, 100, 100) = %g, want 50", got)
+	}
+}
+
+func TestSymlogBeyondThreshold(t *testing.T) {
+	// beyond the linear threshold, symlog should stitch to a log10 tail rather
+	// than keep growing linearly: a value 10x past the threshold should land
+	// only 1 unit past it, not 10.
+	got := symlog(SymLogThresh*10, SymLogThresh)
+	want := SymLogThresh + 1
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("symlog(10t, t) = %g, want %g", got, want)
+	}
+	// and it should still be monotonically increasing with value.
+	if a, b := symlog(SymLogThresh*2, SymLogThresh), symlog(SymLogThresh*4, SymLogThresh); a >= b {
+		t.Errorf("symlog not monotonic: symlog(2t)=%g, symlog(4t)=%g", a, b)
+	}
+}
+
+func TestQualitativeBounds(t *testing.T) {
+	got := qualitativeBounds([]float64{29, 27}, 0, 60)
+	want := []float64{0, 27, 29, 60}
+	if len(got) != len(want) {
+		t.Fatalf("qualitativeBounds({29,27}, 0, 60) = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("qualitativeBounds({29,27}, 0, 60)[%d] = %g, want %g", i, got[i], want[i])
+		}
+	}
+}
+
+func TestQualitativeBoundsReversed(t *testing.T) {
+	// on a reversed domain, bounds must still run in the domain's own
+	// direction so bands render left to right on the axis.
+	got := qualitativeBounds([]float64{27, 29}, 60, 0)
+	want := []float64{60, 29, 27, 0}
+	if len(got) != len(want) {
+		t.Fatalf("qualitativeBounds({27,29}, 60, 0) = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("qualitativeBounds({27,29}, 60, 0)[%d] = %g, want %g", i, got[i], want[i])
+		}
+	}
+}
+
+func TestQualitativeBoundsClampsOutOfRange(t *testing.T) {
+	// a qmeasure outside [scalemin, scalemax] clamps to the nearest edge
+	// instead of producing a band that runs off the rendered axis.
+	got := qualitativeBounds([]float64{-10, 70}, 0, 60)
+	want := []float64{0, 0, 60, 60}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("qualitativeBounds({-10,70}, 0, 60)[%d] = %g, want %g", i, got[i], want[i])
+		}
+	}
+}
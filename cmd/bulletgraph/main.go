@@ -0,0 +1,50 @@
+// Command bulletgraph reads a bullet graph definition (XML or JSON) and writes the
+// rendered SVG to stdout, mirroring how the upstream ajstarks bulletgraph tool works.
+// Example:
+//		bulletgraph -format json graph.json > graph.svg
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	bulletgraph "github.com/NerdGGuy/BulletGraphGo"
+	"github.com/ajstarks/svgo"
+)
+
+func main() {
+	format := flag.String("format", "xml", "input format: xml or json")
+	flag.Parse()
+
+	var r io.Reader = os.Stdin
+	if flag.NArg() > 0 {
+		f, err := os.Open(flag.Arg(0))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var bg *bulletgraph.Bulletgraph
+	var err error
+	switch *format {
+	case "json":
+		bg, err = bulletgraph.LoadJSON(r)
+	default:
+		bg, err = bulletgraph.LoadXML(r)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	canvas := svg.New(os.Stdout)
+	canvas.Start(bg.Flags.Width, bg.Flags.Height)
+	canvas.Rect(0, 0, bg.Flags.Width, bg.Flags.Height, "fill:"+bg.Flags.Bgcolor)
+	bg.Drawbg(bulletgraph.NewSVGRenderer(canvas, bg.Flags.Fontsize))
+	canvas.End()
+}
@@ -0,0 +1,158 @@
+package bulletgraph
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+
+	"github.com/ajstarks/svgo"
+)
+
+// Renderer is the drawing surface Drawbg renders onto. Group/EndGroup bracket a
+// run of primitives sharing a style, mirroring svg.SVG's Gstyle/Gend. style
+// strings follow the same semicolon-separated "prop:value" form Drawbg already
+// builds for svgo, e.g. "fill-opacity:0.5;fill:darkgray"; an empty style means
+// "use whatever the enclosing Group set".
+type Renderer interface {
+	Rect(x, y, w, h int, style string)
+	Line(x1, y1, x2, y2 int, style string)
+	Circle(x, y, radius int, style string)
+	Text(x, y int, s string, style string)
+	Group(style string)
+	EndGroup()
+	Title(s string)
+	// MeasureText returns the rendered width, in pixels, of s at the renderer's
+	// current font and size, so callers can size label offsets without guessing.
+	MeasureText(s string) int
+	// StartInteractive opens an optional tooltip/hyperlink wrapper around the
+	// primitives drawn until the matching EndInteractive; title and href may
+	// each be empty. Renderers with no notion of either simply no-op.
+	StartInteractive(title, href string)
+	EndInteractive()
+	// Script emits a raw <script>/<style> block once, outside any interactive
+	// wrapper; renderers with no notion of embedded script no-op.
+	Script(js string)
+}
+
+// SVGRenderer adapts an *svg.SVG to Renderer, preserving Drawbg's original
+// SVG output for callers that don't need PNG/PDF/etc.
+type SVGRenderer struct {
+	Canvas   *svg.SVG
+	Fontsize int
+
+	linkOpen  bool
+	groupOpen bool
+}
+
+// NewSVGRenderer wraps canvas for use with Drawbg. fontsize should match the
+// Bulletgraph's Options.Fontsize so MeasureText stays consistent with what's
+// actually drawn.
+func NewSVGRenderer(canvas *svg.SVG, fontsize int) *SVGRenderer {
+	return &SVGRenderer{Canvas: canvas, Fontsize: fontsize}
+}
+
+func (r *SVGRenderer) Rect(x, y, w, h int, style string) {
+	if style == "" {
+		r.Canvas.Rect(x, y, w, h)
+		return
+	}
+	r.Canvas.Rect(x, y, w, h, style)
+}
+
+func (r *SVGRenderer) Line(x1, y1, x2, y2 int, style string) {
+	if style == "" {
+		r.Canvas.Line(x1, y1, x2, y2)
+		return
+	}
+	r.Canvas.Line(x1, y1, x2, y2, style)
+}
+
+func (r *SVGRenderer) Circle(x, y, radius int, style string) {
+	if style == "" {
+		r.Canvas.Circle(x, y, radius)
+		return
+	}
+	r.Canvas.Circle(x, y, radius, style)
+}
+
+func (r *SVGRenderer) Text(x, y int, s string, style string) {
+	if style == "" {
+		r.Canvas.Text(x, y, s)
+		return
+	}
+	r.Canvas.Text(x, y, s, style)
+}
+
+func (r *SVGRenderer) Group(style string) {
+	r.Canvas.Gstyle(style)
+}
+
+func (r *SVGRenderer) EndGroup() {
+	r.Canvas.Gend()
+}
+
+func (r *SVGRenderer) Title(s string) {
+	r.Canvas.Title(s)
+}
+
+// MeasureText approximates rendered text width; svgo carries no font metrics of
+// its own, so this uses the usual average glyph width for a proportional font.
+func (r *SVGRenderer) MeasureText(s string) int {
+	return int(float64(len(s)) * float64(r.Fontsize) * 0.6)
+}
+
+// StartInteractive opens a <g class="bg-interactive"> carrying an SVG <title>
+// tooltip and, if href is set, an <a xlink:href> hyperlink, so hovering or
+// clicking the primitives drawn until EndInteractive reflects either. It is a
+// no-op when both title and href are empty. Gstyle only ever emits a bare
+// style="..." attribute, so the class has to be written directly. title and
+// href may come from untrusted LoadXML/LoadJSON input: href is XML-escaped
+// here since svgo.Link writes it unescaped, but title is passed through as-is
+// since svgo.Title/Link already XML-escape their text/description argument —
+// escaping it again here would show literal "&amp;" etc. in the tooltip.
+func (r *SVGRenderer) StartInteractive(title, href string) {
+	if title == "" && href == "" {
+		return
+	}
+	fmt.Fprint(r.Canvas.Writer, `<g class="bg-interactive">`+"\n")
+	r.groupOpen = true
+	if href != "" {
+		r.Canvas.Link(escapeXML(href), title)
+		r.linkOpen = true
+	}
+	if title != "" {
+		r.Canvas.Title(title)
+	}
+}
+
+// EndInteractive closes whatever StartInteractive opened.
+func (r *SVGRenderer) EndInteractive() {
+	if r.linkOpen {
+		r.Canvas.LinkEnd()
+		r.linkOpen = false
+	}
+	if r.groupOpen {
+		r.Canvas.Gend()
+		r.groupOpen = false
+	}
+}
+
+// Script writes js (a complete <style>/<script> block) directly to the
+// underlying SVG document.
+func (r *SVGRenderer) Script(js string) {
+	if js == "" {
+		return
+	}
+	fmt.Fprint(r.Canvas.Writer, js)
+}
+
+var _ Renderer = (*SVGRenderer)(nil)
+
+// escapeXML escapes s for safe interpolation into an XML attribute value, so
+// untrusted Href text from LoadXML/LoadJSON can't break out of the
+// xlink:href attribute svgo.Link writes unescaped.
+func escapeXML(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
@@ -0,0 +1,252 @@
+package bulletgraph
+
+import (
+	"fmt"
+	"image/color"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// TermAttr is the foreground/background color pair attached to one rendered
+// terminal cell. A nil color means "use the terminal's default".
+type TermAttr struct {
+	Fg, Bg color.Color
+}
+
+// TermRenderer rasterizes a Bulletgraph into a Cols x Rows character grid using
+// Unicode half-block glyphs (▀ ▄ █) for 2x vertical sub-cell resolution: each
+// terminal row packs two pixel rows via independent foreground/background
+// colors, the same trick terminal image viewers use. Qualitative bands, the
+// projected-measure overlay and the measure bar all fill the same sub-pixel
+// layer, a later Rect overwriting an earlier one, so the measure bar drawn last
+// lies on top of the bands; bands get no separate background layer, and a cell
+// shows its top sub-pixel color as the glyph's foreground and its bottom one as
+// its background, whichever Rect painted them. The
+// comparative mark is drawn as a literal '│' (line mark) or '●' (circle mark)
+// glyph rather than a half-block, since it needs to stay legible at 1-column
+// width. ANSI truecolor escapes carry Barcolor/Datacolor/Compcolor unless
+// NoColor is set, in which case Render emits plain glyphs only.
+type TermRenderer struct {
+	Cols, Rows              int
+	PixelWidth, PixelHeight int
+	NoColor                 bool
+
+	cell      [][]color.Color // Rows*2 x Cols sub-pixel rows, last Rect wins
+	markRune  [][]rune        // Rows*2 x Cols comparative-mark/text glyph overlay
+	markColor [][]color.Color
+}
+
+// NewTermRenderer builds a renderer for a cols x rows terminal grid. pixelWidth
+// and pixelHeight should match the Bulletgraph's Options.Width/Height so
+// coordinates line up; they default to New's own defaults (1024x800) if zero.
+func NewTermRenderer(cols, rows, pixelWidth, pixelHeight int, noColor bool) *TermRenderer {
+	if pixelWidth <= 0 {
+		pixelWidth = 1024
+	}
+	if pixelHeight <= 0 {
+		pixelHeight = 800
+	}
+	subrows := rows * 2
+	t := &TermRenderer{
+		Cols: cols, Rows: rows,
+		PixelWidth: pixelWidth, PixelHeight: pixelHeight,
+		NoColor: noColor,
+	}
+	t.cell = make([][]color.Color, subrows)
+	t.markRune = make([][]rune, subrows)
+	t.markColor = make([][]color.Color, subrows)
+	for i := 0; i < subrows; i++ {
+		t.cell[i] = make([]color.Color, cols)
+		t.markRune[i] = make([]rune, cols)
+		t.markColor[i] = make([]color.Color, cols)
+	}
+	return t
+}
+
+// px maps a Bulletgraph pixel coordinate onto this renderer's sub-pixel grid
+// (Cols wide, Rows*2 tall).
+func (t *TermRenderer) px(x, y int) (col, row int) {
+	col = x * t.Cols / t.PixelWidth
+	row = y * t.Rows * 2 / t.PixelHeight
+	return col, row
+}
+
+func (t *TermRenderer) setCell(col, row int, c color.Color) {
+	if row < 0 || row >= len(t.cell) || col < 0 || col >= t.Cols {
+		return
+	}
+	t.cell[row][col] = c
+}
+
+func (t *TermRenderer) setMark(col, row int, glyph rune, c color.Color) {
+	if row < 0 || row >= len(t.markRune) || col < 0 || col >= t.Cols {
+		return
+	}
+	t.markRune[row][col] = glyph
+	t.markColor[row][col] = c
+}
+
+func (t *TermRenderer) Rect(x, y, w, h int, style string) {
+	c := styleColor(style)
+	x0, y0 := t.px(x, y)
+	x1, y1 := t.px(x+w, y+h)
+	for row := y0; row < y1; row++ {
+		for col := x0; col < x1; col++ {
+			t.setCell(col, row, c)
+		}
+	}
+}
+
+// Line draws the comparative mark's non-circle form as a vertical '│'; Drawbg
+// only ever calls this with x1 == x2, so no general line-drawing is needed.
+func (t *TermRenderer) Line(x1, y1, x2, y2 int, style string) {
+	c := styleColor(style)
+	col, row0 := t.px(x1, y1)
+	_, row1 := t.px(x2, y2)
+	if row0 > row1 {
+		row0, row1 = row1, row0
+	}
+	for row := row0; row <= row1; row++ {
+		t.setMark(col, row, '│', c)
+	}
+}
+
+// Circle draws the comparative mark's circle form as a single '●' glyph.
+func (t *TermRenderer) Circle(x, y, radius int, style string) {
+	c := styleColor(style)
+	col, row := t.px(x, y)
+	t.setMark(col, row, '●', c)
+}
+
+func (t *TermRenderer) Text(x, y int, s string, style string) {
+	c := styleColor(style)
+	col, row := t.px(x, y)
+	start := col
+	switch {
+	case strings.Contains(style, "text-anchor:end"):
+		start = col - utf8.RuneCountInString(s)
+	case strings.Contains(style, "text-anchor:middle"):
+		start = col - utf8.RuneCountInString(s)/2
+	}
+	pos := start
+	for _, ch := range s {
+		t.setMark(pos, row, ch, c)
+		pos++
+	}
+}
+
+func (t *TermRenderer) Group(style string) {}
+func (t *TermRenderer) EndGroup()           {}
+func (t *TermRenderer) Title(s string)      {}
+
+// StartInteractive, EndInteractive, and Script are no-ops: a character grid
+// has no notion of tooltips, hyperlinks, or embedded script.
+func (t *TermRenderer) StartInteractive(title, href string) {}
+func (t *TermRenderer) EndInteractive()                     {}
+func (t *TermRenderer) Script(js string)                    {}
+
+// MeasureText converts s's length back into Bulletgraph pixel units, the
+// inverse of the Cols/PixelWidth scale px uses, so Drawbg's left-margin sizing
+// works in the same units regardless of which Renderer is in use.
+func (t *TermRenderer) MeasureText(s string) int {
+	if t.Cols == 0 {
+		return 0
+	}
+	return utf8.RuneCountInString(s) * t.PixelWidth / t.Cols
+}
+
+var _ Renderer = (*TermRenderer)(nil)
+
+// snapshot combines the sub-pixel cell/mark layers into a Rows x Cols grid,
+// picking a half-block glyph (▀/▄/█) per cell from its two packed pixel rows,
+// unless a comparative-mark or text glyph was written there, which always wins.
+func (t *TermRenderer) snapshot() ([][]rune, [][]TermAttr) {
+	glyphs := make([][]rune, t.Rows)
+	attrs := make([][]TermAttr, t.Rows)
+	for row := 0; row < t.Rows; row++ {
+		glyphs[row] = make([]rune, t.Cols)
+		attrs[row] = make([]TermAttr, t.Cols)
+		top, bottom := 2*row, 2*row+1
+		for col := 0; col < t.Cols; col++ {
+			if g := t.markRune[top][col]; g != 0 {
+				glyphs[row][col] = g
+				attrs[row][col] = TermAttr{Fg: t.markColor[top][col]}
+				continue
+			}
+			if g := t.markRune[bottom][col]; g != 0 {
+				glyphs[row][col] = g
+				attrs[row][col] = TermAttr{Fg: t.markColor[bottom][col]}
+				continue
+			}
+			topc, botc := t.cell[top][col], t.cell[bottom][col]
+			switch {
+			case topc == nil && botc == nil:
+				glyphs[row][col] = ' '
+			case topc != nil && botc != nil && topc == botc:
+				glyphs[row][col], attrs[row][col] = '█', TermAttr{Fg: topc}
+			case topc != nil && botc != nil:
+				glyphs[row][col], attrs[row][col] = '▀', TermAttr{Fg: topc, Bg: botc}
+			case topc != nil:
+				glyphs[row][col], attrs[row][col] = '▀', TermAttr{Fg: topc}
+			default:
+				glyphs[row][col], attrs[row][col] = '▄', TermAttr{Fg: botc}
+			}
+		}
+	}
+	return glyphs, attrs
+}
+
+// Snapshot returns the rendered Rows x Cols glyph and attribute buffers without
+// writing them anywhere, so a caller can composite the bullet graph inside a
+// larger TUI layout.
+func (t *TermRenderer) Snapshot() ([][]rune, [][]TermAttr) {
+	return t.snapshot()
+}
+
+// Render writes the rendered grid to w, one line per row. Unless NoColor is
+// set, each cell is wrapped in 24-bit ANSI SGR escapes for its foreground and
+// background, reset immediately after so unrelated terminal state is untouched.
+func (t *TermRenderer) Render(w io.Writer) error {
+	glyphs, attrs := t.snapshot()
+	for row := range glyphs {
+		for col, g := range glyphs[row] {
+			s := string(g)
+			if !t.NoColor {
+				s = ansiGlyph(g, attrs[row][col])
+			}
+			if _, err := io.WriteString(w, s); err != nil {
+				return err
+			}
+		}
+		if _, err := io.WriteString(w, "\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func ansiGlyph(glyph rune, attr TermAttr) string {
+	var sgr []string
+	if attr.Fg != nil {
+		r, g, b, _ := attr.Fg.RGBA()
+		sgr = append(sgr, fmt.Sprintf("38;2;%d;%d;%d", r>>8, g>>8, b>>8))
+	}
+	if attr.Bg != nil {
+		r, g, b, _ := attr.Bg.RGBA()
+		sgr = append(sgr, fmt.Sprintf("48;2;%d;%d;%d", r>>8, g>>8, b>>8))
+	}
+	if len(sgr) == 0 {
+		return string(glyph)
+	}
+	return "\x1b[" + strings.Join(sgr, ";") + "m" + string(glyph) + "\x1b[0m"
+}
+
+// TermDraw renders bg into a cols x rows character grid and writes it to w,
+// honoring Barcolor/Datacolor/Compcolor via ANSI truecolor escapes unless
+// noColor is set.
+func (bg *Bulletgraph) TermDraw(w io.Writer, cols, rows int, noColor bool) error {
+	t := NewTermRenderer(cols, rows, bg.Flags.Width, bg.Flags.Height, noColor)
+	bg.Drawbg(t)
+	return t.Render(w)
+}